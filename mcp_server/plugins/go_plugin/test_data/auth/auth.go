@@ -0,0 +1,158 @@
+// Package auth provides JWT-based signup, login, and session handlers
+// for the user service, independent of the concrete user storage.
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/internal/errno"
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/internal/handler"
+)
+
+// jwtSecret signs and verifies issued tokens. It defaults to a value that
+// must never be used in production; callers wire in the real secret via
+// SetJWTSecret before serving any requests (see internal/config.Config.JWTSecret).
+var jwtSecret = []byte("change-me-in-production")
+
+// SetJWTSecret overrides the secret used to sign and verify tokens.
+func SetJWTSecret(secret string) {
+	jwtSecret = []byte(secret)
+}
+
+// Account is the minimal user representation the auth package needs.
+// It is decoupled from model.User so that auth depends on neither the
+// model, service nor store packages; it only uses internal/errno and
+// internal/handler to write its responses.
+type Account struct {
+	ID           int
+	Email        string
+	PasswordHash string
+	IsAdmin      bool
+}
+
+// AccountStore is implemented by whatever backs the user service.
+type AccountStore interface {
+	FindByEmail(email string) (*Account, error)
+	CreateAccount(email, passwordHash string) (*Account, error)
+	Authenticate(email, password string) (*Account, error)
+}
+
+// Claims are the custom JWT claims embedded in issued tokens.
+type Claims struct {
+	UserID  int    `json:"user_id"`
+	Email   string `json:"email"`
+	IsAdmin bool   `json:"is_admin"`
+	jwt.RegisteredClaims
+}
+
+// SignupRequest is the expected payload for POST /auth/signup.
+type SignupRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// LoginRequest is the expected payload for POST /auth/login.
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// LoginResponse carries the signed token returned on a successful login.
+type LoginResponse struct {
+	Token string `json:"token"`
+}
+
+const tokenTTL = 24 * time.Hour
+
+// GenerateToken signs a new HS256 JWT for the given account.
+func GenerateToken(account *Account) (string, error) {
+	claims := Claims{
+		UserID:  account.ID,
+		Email:   account.Email,
+		IsAdmin: account.IsAdmin,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(tokenTTL)),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(jwtSecret)
+}
+
+// ParseToken validates a token string and returns its claims.
+func ParseToken(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return jwtSecret, nil
+	})
+	if err != nil || !token.Valid {
+		return nil, errors.New("invalid or expired token")
+	}
+
+	return claims, nil
+}
+
+// SignupHandler registers a new account and returns its signed token.
+func SignupHandler(store AccountStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req SignupRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			handler.SendResponse(w, http.StatusOK, errno.ErrBind, nil)
+			return
+		}
+
+		hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+		if err != nil {
+			handler.SendResponse(w, http.StatusOK, errno.ErrUnknown, nil)
+			return
+		}
+
+		account, err := store.CreateAccount(req.Email, string(hash))
+		if err != nil {
+			handler.SendResponse(w, http.StatusOK, errno.ErrConflict, nil)
+			return
+		}
+
+		token, err := GenerateToken(account)
+		if err != nil {
+			handler.SendResponse(w, http.StatusOK, errno.ErrUnknown, nil)
+			return
+		}
+
+		handler.SendResponse(w, http.StatusOK, nil, LoginResponse{Token: token})
+	}
+}
+
+// LoginHandler authenticates an account and returns a signed token.
+func LoginHandler(store AccountStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req LoginRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			handler.SendResponse(w, http.StatusOK, errno.ErrBind, nil)
+			return
+		}
+
+		account, err := store.Authenticate(req.Email, req.Password)
+		if err != nil {
+			handler.SendResponse(w, http.StatusOK, errno.ErrInvalidCredentials, nil)
+			return
+		}
+
+		token, err := GenerateToken(account)
+		if err != nil {
+			handler.SendResponse(w, http.StatusOK, errno.ErrUnknown, nil)
+			return
+		}
+
+		handler.SendResponse(w, http.StatusOK, nil, LoginResponse{Token: token})
+	}
+}
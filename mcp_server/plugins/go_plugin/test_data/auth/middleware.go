@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/internal/errno"
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/internal/handler"
+)
+
+var errMissingToken = errors.New("missing bearer token")
+
+type contextKey string
+
+const userContextKey contextKey = "auth.user"
+
+// RequireAuth wraps a handler so that it only runs for requests carrying
+// a valid bearer token, attaching the resulting claims to the request
+// context so downstream handlers can retrieve them via UserFromContext.
+func RequireAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, err := claimsFromRequest(r)
+		if err != nil {
+			handler.SendResponse(w, http.StatusOK, errno.ErrTokenInvalid, nil)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), userContextKey, claims)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequireAdmin wraps a handler so that write methods (POST/PUT/DELETE)
+// additionally require the authenticated user to carry the is_admin role.
+// It must be applied after RequireAuth.
+func RequireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost, http.MethodPut, http.MethodDelete:
+			claims, ok := UserFromContext(r.Context())
+			if !ok || !claims.IsAdmin {
+				handler.SendResponse(w, http.StatusOK, errno.ErrPermissionDenied, nil)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// UserFromContext retrieves the authenticated user's claims, if any.
+func UserFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(userContextKey).(*Claims)
+	return claims, ok
+}
+
+func claimsFromRequest(r *http.Request) (*Claims, error) {
+	header := r.Header.Get("Authorization")
+	const prefix = "Bearer "
+	if !strings.HasPrefix(header, prefix) {
+		return nil, errMissingToken
+	}
+
+	return ParseToken(strings.TrimPrefix(header, prefix))
+}
+
+// OptionalClaims returns the request's claims if it carries a valid
+// bearer token, and ok=false (with no error) otherwise. Unlike
+// RequireAuth, a missing or invalid token is not a failure here — it's
+// for endpoints like the session probe that serve both logged-in and
+// logged-out callers.
+func OptionalClaims(r *http.Request) (*Claims, bool) {
+	claims, err := claimsFromRequest(r)
+	if err != nil {
+		return nil, false
+	}
+	return claims, true
+}
@@ -0,0 +1,29 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// SessionResponse mirrors the common session-probe pattern SPAs use to
+// decide whether a visitor is currently logged in.
+type SessionResponse struct {
+	LoggedIn bool    `json:"loggedIn"`
+	User     *Claims `json:"user,omitempty"`
+}
+
+// SessionHandler serves GET /auth/session, reporting whether the request
+// carried a valid token and, if so, who it belongs to. It is meant to be
+// mounted unwrapped (no RequireAuth) since logged-out callers must still
+// get a 200 with loggedIn: false rather than a 401.
+func SessionHandler(w http.ResponseWriter, r *http.Request) {
+	claims, ok := OptionalClaims(r)
+
+	resp := SessionResponse{LoggedIn: ok}
+	if ok {
+		resp.User = claims
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/auth"
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/internal/handler"
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/internal/service"
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/internal/store"
+)
+
+// TestServerIntegration boots the handler layer against an in-memory
+// store, the same way main() does, and exercises it end to end.
+func TestServerIntegration(t *testing.T) {
+	svc := service.NewUserService(store.NewInMemoryStore())
+	router := handler.NewRouter(svc)
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	createResp, err := http.Post(srv.URL+"/users", "application/json", strings.NewReader(`{"name":"Ada","email":"ada@example.com"}`))
+	if err != nil {
+		t.Fatalf("POST /users error = %v", err)
+	}
+	defer createResp.Body.Close()
+	if createResp.StatusCode != http.StatusCreated {
+		t.Fatalf("POST /users status = %d, want %d", createResp.StatusCode, http.StatusCreated)
+	}
+
+	getResp, err := http.Get(srv.URL + "/users/1")
+	if err != nil {
+		t.Fatalf("GET /users/1 error = %v", err)
+	}
+	defer getResp.Body.Close()
+	if getResp.StatusCode != http.StatusOK {
+		t.Fatalf("GET /users/1 status = %d, want %d", getResp.StatusCode, http.StatusOK)
+	}
+}
+
+// TestMuxTopology exercises main's actual route wiring (newMux), not just
+// the inner handler.NewRouter, so a mismatch between the outer mux's
+// patterns and the inner router's patterns (e.g. "/users/" vs
+// "POST /users") shows up here.
+func TestMuxTopology(t *testing.T) {
+	svc := service.NewUserService(store.NewInMemoryStore())
+	accountStore := &accountStoreAdapter{svc: svc}
+
+	srv := httptest.NewServer(newMux(svc, accountStore))
+	defer srv.Close()
+
+	token, err := auth.GenerateToken(&auth.Account{ID: 1, Email: "admin@example.com", IsAdmin: true})
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/users", strings.NewReader(`{"name":"Ada","email":"ada@example.com"}`))
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("POST /users error = %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("POST /users status = %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+}
+
+// TestAuthSignupLogin exercises the real /auth/signup then /auth/login
+// round trip, so the authentication actually served stays pinned to
+// accountStoreAdapter.Authenticate (backed by service.Authenticate)
+// rather than a parallel reimplementation drifting from it.
+func TestAuthSignupLogin(t *testing.T) {
+	svc := service.NewUserService(store.NewInMemoryStore())
+	accountStore := &accountStoreAdapter{svc: svc}
+
+	srv := httptest.NewServer(newMux(svc, accountStore))
+	defer srv.Close()
+
+	signupResp, err := http.Post(srv.URL+"/auth/signup", "application/json", strings.NewReader(`{"email":"ada@example.com","password":"correct-horse"}`))
+	if err != nil {
+		t.Fatalf("POST /auth/signup error = %v", err)
+	}
+	defer signupResp.Body.Close()
+	if signupResp.StatusCode != http.StatusOK {
+		t.Fatalf("POST /auth/signup status = %d, want %d", signupResp.StatusCode, http.StatusOK)
+	}
+
+	wrongResp, err := http.Post(srv.URL+"/auth/login", "application/json", strings.NewReader(`{"email":"ada@example.com","password":"wrong"}`))
+	if err != nil {
+		t.Fatalf("POST /auth/login (wrong password) error = %v", err)
+	}
+	defer wrongResp.Body.Close()
+	var wrongBody struct {
+		Code int `json:"code"`
+	}
+	if err := json.NewDecoder(wrongResp.Body).Decode(&wrongBody); err != nil {
+		t.Fatalf("decoding /auth/login (wrong password) response: %v", err)
+	}
+	if wrongBody.Code == 0 {
+		t.Errorf("POST /auth/login with wrong password succeeded, want a failure code")
+	}
+
+	loginResp, err := http.Post(srv.URL+"/auth/login", "application/json", strings.NewReader(`{"email":"ada@example.com","password":"correct-horse"}`))
+	if err != nil {
+		t.Fatalf("POST /auth/login error = %v", err)
+	}
+	defer loginResp.Body.Close()
+	var loginBody struct {
+		Code int `json:"code"`
+		Data struct {
+			Token string `json:"token"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(loginResp.Body).Decode(&loginBody); err != nil {
+		t.Fatalf("decoding /auth/login response: %v", err)
+	}
+	if loginBody.Code != 0 {
+		t.Fatalf("POST /auth/login code = %d, want %d", loginBody.Code, 0)
+	}
+
+	claims, err := auth.ParseToken(loginBody.Data.Token)
+	if err != nil {
+		t.Fatalf("ParseToken() error = %v", err)
+	}
+	if claims.Email != "ada@example.com" {
+		t.Errorf("claims.Email = %q, want %q", claims.Email, "ada@example.com")
+	}
+	if claims.UserID == 0 {
+		t.Errorf("claims.UserID = 0, want the persisted user's ID")
+	}
+}
@@ -0,0 +1,95 @@
+// Command server wires together the user service's config, store,
+// service, and handler layers and starts listening.
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/auth"
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/internal/config"
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/internal/handler"
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/internal/model"
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/internal/service"
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/internal/store"
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/middleware"
+)
+
+// accountStoreAdapter satisfies auth.AccountStore on top of the service
+// layer, so the auth package never needs to know about model.User.
+type accountStoreAdapter struct {
+	svc service.UserService
+}
+
+func (a *accountStoreAdapter) FindByEmail(email string) (*auth.Account, error) {
+	user, err := a.svc.FindByEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.Account{ID: user.ID, Email: user.Email, PasswordHash: user.PasswordHash, IsAdmin: user.IsAdmin}, nil
+}
+
+func (a *accountStoreAdapter) CreateAccount(email, passwordHash string) (*auth.Account, error) {
+	created, err := a.svc.CreateUser(model.User{Email: email, PasswordHash: passwordHash})
+	if err != nil {
+		return nil, err
+	}
+	return &auth.Account{ID: created.ID, Email: created.Email, PasswordHash: created.PasswordHash, IsAdmin: created.IsAdmin}, nil
+}
+
+func (a *accountStoreAdapter) Authenticate(email, password string) (*auth.Account, error) {
+	user, err := a.svc.Authenticate(email, password)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.Account{ID: user.ID, Email: user.Email, PasswordHash: user.PasswordHash, IsAdmin: user.IsAdmin}, nil
+}
+
+// newMux wires the full route topology used by main, factored out so
+// tests can exercise it directly instead of just the inner handler.NewRouter.
+func newMux(svc service.UserService, accountStore auth.AccountStore) http.Handler {
+	mux := http.NewServeMux()
+
+	// Registered both with and without the trailing slash: ServeMux
+	// otherwise 301-redirects a bare "/users" request to "/users/" before
+	// RequireAuth/RequireAdmin ever run, and "/users/" alone doesn't match
+	// the inner router's exact "POST /users" pattern.
+	userHandler := auth.RequireAuth(auth.RequireAdmin(handler.NewRouter(svc)))
+	mux.Handle("/users", userHandler)
+	mux.Handle("/users/", userHandler)
+
+	mux.HandleFunc("/auth/signup", auth.SignupHandler(accountStore))
+	mux.HandleFunc("/auth/login", auth.LoginHandler(accountStore))
+	mux.HandleFunc("/auth/session", auth.SessionHandler)
+
+	stats := middleware.NewStats(mux)
+	mux.HandleFunc("/stats", middleware.StatsHandler(stats))
+	mux.HandleFunc("/metrics", middleware.MetricsHandler(stats))
+
+	return stats
+}
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("loading config: %v", err)
+	}
+	auth.SetJWTSecret(cfg.JWTSecret)
+
+	var userStore store.UserStore
+	if cfg.DBDSN != "" {
+		userStore, err = store.OpenSQLStore(cfg.DBDSN)
+		if err != nil {
+			log.Fatalf("opening database: %v", err)
+		}
+	} else {
+		userStore = store.NewInMemoryStore()
+	}
+
+	svc := service.NewUserService(userStore)
+	accountStore := &accountStoreAdapter{svc: svc}
+
+	fmt.Printf("Server starting on port %s (api %s)\n", cfg.Port, cfg.APIVersion)
+	log.Fatal(http.ListenAndServe(":"+cfg.Port, newMux(svc, accountStore)))
+}
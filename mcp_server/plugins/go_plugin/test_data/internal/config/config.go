@@ -0,0 +1,61 @@
+// Package config loads the user service's runtime settings from
+// conf/config.yaml, with environment variables taking precedence.
+package config
+
+import (
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Config holds all settings the rest of the service depends on.
+type Config struct {
+	Port           string `mapstructure:"port"`
+	DBDSN          string `mapstructure:"db_dsn"`
+	JWTSecret      string `mapstructure:"jwt_secret"`
+	DefaultTimeout int    `mapstructure:"default_timeout"`
+	APIVersion     string `mapstructure:"api_version"`
+}
+
+func defaults() Config {
+	return Config{
+		Port:           "8080",
+		DBDSN:          "",
+		JWTSecret:      "change-me-in-production",
+		DefaultTimeout: 30,
+		APIVersion:     "v1",
+	}
+}
+
+// Load reads conf/config.yaml relative to the working directory and
+// overlays any matching environment variables (e.g. PORT, DB_DSN,
+// JWT_SECRET, DEFAULT_TIMEOUT, API_VERSION).
+func Load() (*Config, error) {
+	cfg := defaults()
+
+	v := viper.New()
+	v.SetConfigName("config")
+	v.SetConfigType("yaml")
+	v.AddConfigPath("conf")
+
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+
+	v.SetDefault("port", cfg.Port)
+	v.SetDefault("db_dsn", cfg.DBDSN)
+	v.SetDefault("jwt_secret", cfg.JWTSecret)
+	v.SetDefault("default_timeout", cfg.DefaultTimeout)
+	v.SetDefault("api_version", cfg.APIVersion)
+
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, err
+		}
+	}
+
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
@@ -0,0 +1,65 @@
+// Package errno defines the user service's machine-readable error codes,
+// distinct from the HTTP status they map to.
+package errno
+
+import "errors"
+
+// Errno is a typed, machine-readable error. Code is stable across
+// releases so clients can switch on it instead of parsing Message.
+type Errno struct {
+	Code    int
+	Message string
+}
+
+// Error implements the error interface.
+func (e *Errno) Error() string {
+	return e.Message
+}
+
+// Sentinel errors, grouped by the HTTP status their code range maps to
+// (see StatusFromCode): 400xx bad request, 401xx unauthorized, 403xx
+// forbidden, 404xx not found, 500xx internal/database.
+var (
+	OK = &Errno{Code: 0, Message: "ok"}
+
+	ErrInvalidParam = &Errno{Code: 40000, Message: "invalid parameter"}
+	ErrBind         = &Errno{Code: 40001, Message: "failed to bind request body"}
+
+	ErrTokenInvalid       = &Errno{Code: 40100, Message: "invalid or expired token"}
+	ErrInvalidCredentials = &Errno{Code: 40101, Message: "invalid credentials"}
+
+	ErrPermissionDenied = &Errno{Code: 40300, Message: "permission denied"}
+
+	ErrUserNotFound = &Errno{Code: 40400, Message: "user not found"}
+
+	ErrConflict = &Errno{Code: 40900, Message: "resource already exists"}
+
+	ErrDatabase = &Errno{Code: 50000, Message: "database error"}
+	ErrUnknown  = &Errno{Code: 50001, Message: "internal server error"}
+)
+
+// StatusFromCode derives the HTTP status an Errno code maps to: the
+// code's first three digits are the status (e.g. 40400 -> 404).
+func StatusFromCode(code int) int {
+	if code == 0 {
+		return 200
+	}
+	return code / 100
+}
+
+// DecodeErr unwraps err (via errors.As) to find the nearest *Errno,
+// returning its code and message. Errors that were never wrapped from an
+// Errno decode to ErrUnknown's code, with the error's own message, so the
+// caller always gets a stable code.
+func DecodeErr(err error) (code int, message string) {
+	if err == nil {
+		return OK.Code, OK.Message
+	}
+
+	var e *Errno
+	if errors.As(err, &e) {
+		return e.Code, e.Message
+	}
+
+	return ErrUnknown.Code, err.Error()
+}
@@ -0,0 +1,51 @@
+package errno
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestDecodeErr(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantCode    int
+		wantMessage string
+	}{
+		{name: "nil error", err: nil, wantCode: OK.Code, wantMessage: OK.Message},
+		{name: "sentinel errno", err: ErrUserNotFound, wantCode: ErrUserNotFound.Code, wantMessage: ErrUserNotFound.Message},
+		{name: "wrapped errno", err: fmt.Errorf("loading user: %w", ErrUserNotFound), wantCode: ErrUserNotFound.Code, wantMessage: ErrUserNotFound.Message},
+		{name: "unrelated error", err: errors.New("boom"), wantCode: ErrUnknown.Code, wantMessage: "boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, message := DecodeErr(tt.err)
+			if code != tt.wantCode || message != tt.wantMessage {
+				t.Errorf("DecodeErr() = (%d, %q), want (%d, %q)", code, message, tt.wantCode, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestStatusFromCode(t *testing.T) {
+	tests := []struct {
+		code int
+		want int
+	}{
+		{code: 0, want: 200},
+		{code: ErrInvalidParam.Code, want: 400},
+		{code: ErrTokenInvalid.Code, want: 401},
+		{code: ErrPermissionDenied.Code, want: 403},
+		{code: ErrUserNotFound.Code, want: 404},
+		{code: ErrConflict.Code, want: 409},
+		{code: ErrDatabase.Code, want: 500},
+	}
+
+	for _, tt := range tests {
+		if got := StatusFromCode(tt.code); got != tt.want {
+			t.Errorf("StatusFromCode(%d) = %d, want %d", tt.code, got, tt.want)
+		}
+	}
+}
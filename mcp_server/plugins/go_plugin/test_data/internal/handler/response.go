@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/internal/errno"
+)
+
+// response is the envelope every handler response is serialized into.
+type response struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data"`
+}
+
+// SendResponse writes a {code, message, data} JSON body. On success
+// (err == nil) it uses successStatus as-is, so callers keep their
+// intended per-operation status (201 Created, 204 No Content, ...); on
+// failure the status is derived from err's errno code instead. Clients
+// always get a machine-readable failure reason alongside the status line.
+func SendResponse(w http.ResponseWriter, successStatus int, err error, data interface{}) {
+	code, message := errno.DecodeErr(err)
+	status := successStatus
+	if err != nil {
+		status = errno.StatusFromCode(code)
+		if status >= http.StatusInternalServerError {
+			log.Printf("request failed: code=%d message=%s", code, message)
+		}
+	}
+
+	if status == http.StatusNoContent {
+		w.WriteHeader(status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response{Code: code, Message: message, Data: data})
+}
@@ -0,0 +1,127 @@
+// Package handler wires the user service's business logic to HTTP, using
+// Go 1.22's method-aware ServeMux patterns.
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/internal/errno"
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/internal/model"
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/internal/service"
+)
+
+// MethodNotAllowedHandler logs the offending (method, path, status) tuple
+// before responding, so ops can track misbehaving clients.
+func MethodNotAllowedHandler(w http.ResponseWriter, r *http.Request) {
+	log.Printf("method not allowed: method=%s path=%s status=%d", r.Method, r.URL.Path, http.StatusMethodNotAllowed)
+	http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+}
+
+// NotFoundHandler logs the offending (method, path, status) tuple before
+// responding, so ops can track misbehaving clients.
+func NotFoundHandler(w http.ResponseWriter, r *http.Request) {
+	log.Printf("not found: method=%s path=%s status=%d", r.Method, r.URL.Path, http.StatusNotFound)
+	http.Error(w, "Not found", http.StatusNotFound)
+}
+
+// NewRouter builds the HTTP handler for the user service, composable for
+// tests since it takes a service.UserService rather than reaching for
+// globals.
+func NewRouter(svc service.UserService) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /users/{id}", getUserHandler(svc))
+	mux.HandleFunc("POST /users", createUserHandler(svc))
+	mux.HandleFunc("PUT /users/{id}", updateUserHandler(svc))
+	mux.HandleFunc("DELETE /users/{id}", deleteUserHandler(svc))
+
+	// Bare, method-less patterns: ServeMux only falls back to these when
+	// no method-specific pattern matches the request's method, which is
+	// exactly how to hook a custom 405 responder into this mux style.
+	mux.HandleFunc("/users", MethodNotAllowedHandler)
+	mux.HandleFunc("/users/{id}", MethodNotAllowedHandler)
+
+	mux.HandleFunc("/", NotFoundHandler)
+
+	return mux
+}
+
+func getUserHandler(svc service.UserService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			SendResponse(w, http.StatusOK, errno.ErrInvalidParam, nil)
+			return
+		}
+
+		user, err := svc.GetUser(id)
+		if err != nil {
+			SendResponse(w, http.StatusOK, err, nil)
+			return
+		}
+
+		SendResponse(w, http.StatusOK, nil, user)
+	}
+}
+
+func createUserHandler(svc service.UserService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var user model.User
+		if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
+			SendResponse(w, http.StatusCreated, errno.ErrBind, nil)
+			return
+		}
+
+		created, err := svc.CreateUser(user)
+		if err != nil {
+			SendResponse(w, http.StatusCreated, err, nil)
+			return
+		}
+
+		SendResponse(w, http.StatusCreated, nil, created)
+	}
+}
+
+func updateUserHandler(svc service.UserService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			SendResponse(w, http.StatusOK, errno.ErrInvalidParam, nil)
+			return
+		}
+
+		var user model.User
+		if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
+			SendResponse(w, http.StatusOK, errno.ErrBind, nil)
+			return
+		}
+		user.ID = id
+
+		if err := svc.UpdateUser(user); err != nil {
+			SendResponse(w, http.StatusOK, err, nil)
+			return
+		}
+
+		SendResponse(w, http.StatusOK, nil, nil)
+	}
+}
+
+func deleteUserHandler(svc service.UserService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			SendResponse(w, http.StatusNoContent, errno.ErrInvalidParam, nil)
+			return
+		}
+
+		if err := svc.DeleteUser(id); err != nil {
+			SendResponse(w, http.StatusNoContent, err, nil)
+			return
+		}
+
+		SendResponse(w, http.StatusNoContent, nil, nil)
+	}
+}
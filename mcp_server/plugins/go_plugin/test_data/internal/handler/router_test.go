@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/internal/service"
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/internal/store"
+)
+
+func TestRouter(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+	}{
+		{name: "create user", method: http.MethodPost, path: "/users", body: `{"name":"Ada","email":"ada@example.com"}`, wantStatus: http.StatusCreated},
+		{name: "get missing user", method: http.MethodGet, path: "/users/99", wantStatus: http.StatusNotFound},
+		{name: "delete missing user", method: http.MethodDelete, path: "/users/99", wantStatus: http.StatusNotFound},
+		{name: "unknown route", method: http.MethodGet, path: "/unknown", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := service.NewUserService(store.NewInMemoryStore())
+			router := NewRouter(svc)
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package model
+
+// Status represents the application status.
+type Status string
+
+const (
+	StatusActive   Status = "active"
+	StatusInactive Status = "inactive"
+	StatusPending  Status = "pending"
+)
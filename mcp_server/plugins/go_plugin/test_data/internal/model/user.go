@@ -0,0 +1,20 @@
+// Package model holds the domain types shared across the user service's
+// layers.
+package model
+
+// User represents a user in the system.
+type User struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Email        string `json:"email"`
+	PasswordHash string `json:"-"`
+	IsAdmin      bool   `json:"is_admin"`
+}
+
+// NewUser creates a new user instance.
+func NewUser(name, email string) *User {
+	return &User{
+		Name:  name,
+		Email: email,
+	}
+}
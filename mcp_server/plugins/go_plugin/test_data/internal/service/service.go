@@ -0,0 +1,78 @@
+// Package service contains the user service's business logic, independent
+// of how it's served over HTTP or persisted.
+package service
+
+import (
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/internal/errno"
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/internal/model"
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/internal/store"
+)
+
+// UserService defines the interface for user operations.
+type UserService interface {
+	GetUser(id int) (*model.User, error)
+	CreateUser(user model.User) (*model.User, error)
+	UpdateUser(user model.User) error
+	DeleteUser(id int) error
+	FindByEmail(email string) (*model.User, error)
+	Authenticate(email, password string) (*model.User, error)
+}
+
+type userServiceImpl struct {
+	store store.UserStore
+}
+
+// NewUserService builds a UserService backed by the given store.
+func NewUserService(s store.UserStore) UserService {
+	return &userServiceImpl{store: s}
+}
+
+// GetUser retrieves a user by their ID.
+func (s *userServiceImpl) GetUser(id int) (*model.User, error) {
+	if id <= 0 {
+		return nil, errno.ErrInvalidParam
+	}
+
+	return s.store.Get(id)
+}
+
+// CreateUser persists a new user and returns the persisted copy, whose
+// ID is assigned by the store.
+func (s *userServiceImpl) CreateUser(user model.User) (*model.User, error) {
+	return s.store.Create(user)
+}
+
+// UpdateUser persists changes to an existing user.
+func (s *userServiceImpl) UpdateUser(user model.User) error {
+	return s.store.Update(user)
+}
+
+// DeleteUser removes a user by their ID.
+func (s *userServiceImpl) DeleteUser(id int) error {
+	return s.store.Delete(id)
+}
+
+// FindByEmail looks up a user by their email address.
+func (s *userServiceImpl) FindByEmail(email string) (*model.User, error) {
+	if email == "" {
+		return nil, errno.ErrInvalidParam
+	}
+
+	return s.store.FindByEmail(email)
+}
+
+// Authenticate verifies a user's credentials and returns the matching user.
+func (s *userServiceImpl) Authenticate(email, password string) (*model.User, error) {
+	user, err := s.FindByEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return nil, errno.ErrInvalidCredentials
+	}
+
+	return user, nil
+}
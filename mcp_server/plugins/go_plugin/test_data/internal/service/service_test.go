@@ -0,0 +1,65 @@
+package service
+
+import (
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/internal/model"
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/internal/store"
+)
+
+func TestUserServiceGetUser(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      int
+		wantErr bool
+	}{
+		{name: "zero id is invalid", id: 0, wantErr: true},
+		{name: "negative id is invalid", id: -1, wantErr: true},
+		{name: "missing id is not found", id: 42, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewUserService(store.NewInMemoryStore())
+			_, err := svc.GetUser(tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetUser(%d) error = %v, wantErr %v", tt.id, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUserServiceAuthenticate(t *testing.T) {
+	s := store.NewInMemoryStore()
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct-horse"), bcrypt.DefaultCost)
+	if err != nil {
+		t.Fatalf("GenerateFromPassword() error = %v", err)
+	}
+	if _, err := s.Create(model.User{Email: "user@example.com", PasswordHash: string(hash)}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	svc := NewUserService(s)
+
+	tests := []struct {
+		name     string
+		email    string
+		password string
+		wantErr  bool
+	}{
+		{name: "correct credentials", email: "user@example.com", password: "correct-horse", wantErr: false},
+		{name: "wrong password", email: "user@example.com", password: "wrong", wantErr: true},
+		{name: "unknown email", email: "nobody@example.com", password: "correct-horse", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.Authenticate(tt.email, tt.password)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
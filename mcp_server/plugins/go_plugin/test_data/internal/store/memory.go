@@ -0,0 +1,84 @@
+package store
+
+import (
+	"sync"
+
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/internal/model"
+)
+
+// InMemoryStore is a UserStore backed by a guarded map, suitable for tests
+// and local development.
+type InMemoryStore struct {
+	mu     sync.RWMutex
+	users  map[int]model.User
+	nextID int
+}
+
+// NewInMemoryStore returns an empty InMemoryStore.
+func NewInMemoryStore() *InMemoryStore {
+	return &InMemoryStore{
+		users:  make(map[int]model.User),
+		nextID: 1,
+	}
+}
+
+func (s *InMemoryStore) Get(id int) (*model.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	user, ok := s.users[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &user, nil
+}
+
+func (s *InMemoryStore) Create(user model.User) (*model.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.users {
+		if existing.Email == user.Email {
+			return nil, ErrConflict
+		}
+	}
+
+	user.ID = s.nextID
+	s.nextID++
+	s.users[user.ID] = user
+	return &user, nil
+}
+
+func (s *InMemoryStore) Update(user model.User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[user.ID]; !ok {
+		return ErrNotFound
+	}
+	s.users[user.ID] = user
+	return nil
+}
+
+func (s *InMemoryStore) Delete(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[id]; !ok {
+		return ErrNotFound
+	}
+	delete(s.users, id)
+	return nil
+}
+
+func (s *InMemoryStore) FindByEmail(email string) (*model.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, user := range s.users {
+		if user.Email == email {
+			return &user, nil
+		}
+	}
+	return nil, ErrNotFound
+}
@@ -0,0 +1,92 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/internal/model"
+)
+
+func TestInMemoryStore(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, s *InMemoryStore)
+	}{
+		{
+			name: "create then get",
+			run: func(t *testing.T, s *InMemoryStore) {
+				created, err := s.Create(model.User{Name: "Ada", Email: "ada@example.com"})
+				if err != nil {
+					t.Fatalf("Create() error = %v", err)
+				}
+				if created.ID != 1 {
+					t.Errorf("Create() ID = %d, want %d", created.ID, 1)
+				}
+				user, err := s.Get(1)
+				if err != nil {
+					t.Fatalf("Get() error = %v", err)
+				}
+				if user.Email != "ada@example.com" {
+					t.Errorf("Email = %q, want %q", user.Email, "ada@example.com")
+				}
+			},
+		},
+		{
+			name: "get missing returns ErrNotFound",
+			run: func(t *testing.T, s *InMemoryStore) {
+				if _, err := s.Get(99); err != ErrNotFound {
+					t.Errorf("Get() error = %v, want %v", err, ErrNotFound)
+				}
+			},
+		},
+		{
+			name: "update missing returns ErrNotFound",
+			run: func(t *testing.T, s *InMemoryStore) {
+				if err := s.Update(model.User{ID: 99}); err != ErrNotFound {
+					t.Errorf("Update() error = %v, want %v", err, ErrNotFound)
+				}
+			},
+		},
+		{
+			name: "delete removes user",
+			run: func(t *testing.T, s *InMemoryStore) {
+				_, _ = s.Create(model.User{Name: "Grace", Email: "grace@example.com"})
+				if err := s.Delete(1); err != nil {
+					t.Fatalf("Delete() error = %v", err)
+				}
+				if _, err := s.Get(1); err != ErrNotFound {
+					t.Errorf("Get() after Delete() error = %v, want %v", err, ErrNotFound)
+				}
+			},
+		},
+		{
+			name: "create with duplicate email returns ErrConflict",
+			run: func(t *testing.T, s *InMemoryStore) {
+				if _, err := s.Create(model.User{Name: "Ada", Email: "ada@example.com"}); err != nil {
+					t.Fatalf("Create() error = %v", err)
+				}
+				if _, err := s.Create(model.User{Name: "Ada Two", Email: "ada@example.com"}); err != ErrConflict {
+					t.Errorf("Create() error = %v, want %v", err, ErrConflict)
+				}
+			},
+		},
+		{
+			name: "find by email",
+			run: func(t *testing.T, s *InMemoryStore) {
+				_, _ = s.Create(model.User{Name: "Linus", Email: "linus@example.com"})
+				user, err := s.FindByEmail("linus@example.com")
+				if err != nil {
+					t.Fatalf("FindByEmail() error = %v", err)
+				}
+				if user.Name != "Linus" {
+					t.Errorf("Name = %q, want %q", user.Name, "Linus")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, NewInMemoryStore())
+		})
+	}
+}
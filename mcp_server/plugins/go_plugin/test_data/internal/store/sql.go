@@ -0,0 +1,158 @@
+package store
+
+import (
+	"database/sql"
+	"embed"
+	"errors"
+	"sort"
+
+	sqlite3 "github.com/mattn/go-sqlite3"
+
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/internal/model"
+)
+
+// migrations embeds the schema SQLStore expects, so OpenSQLStore can
+// apply it to a fresh DSN instead of relying on an operator to do so.
+//
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// SQLStore is a UserStore backed by database/sql. Callers are responsible
+// for opening db with the appropriate driver (see migrations/ for the
+// schema it expects).
+type SQLStore struct {
+	db *sql.DB
+}
+
+// NewSQLStore wraps an already-opened database handle. It does not apply
+// migrations; use OpenSQLStore for that.
+func NewSQLStore(db *sql.DB) *SQLStore {
+	return &SQLStore{db: db}
+}
+
+// OpenSQLStore opens dsn with the sqlite3 driver, applies the embedded
+// migrations (store/migrations/), and returns a SQLStore backed by it.
+func OpenSQLStore(dsn string) (*SQLStore, error) {
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, err
+	}
+
+	if err := applyMigrations(db); err != nil {
+		return nil, err
+	}
+
+	return NewSQLStore(db), nil
+}
+
+// applyMigrations runs every embedded migration in migrations/, in
+// filename order, so a fresh DSN ends up with the schema SQLStore expects.
+func applyMigrations(db *sql.DB) error {
+	entries, err := migrations.ReadDir("migrations")
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		contents, err := migrations.ReadFile("migrations/" + name)
+		if err != nil {
+			return err
+		}
+		if _, err := db.Exec(string(contents)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SQLStore) Get(id int) (*model.User, error) {
+	row := s.db.QueryRow(`SELECT id, name, email, password_hash, is_admin FROM users WHERE id = ?`, id)
+
+	var user model.User
+	if err := row.Scan(&user.ID, &user.Name, &user.Email, &user.PasswordHash, &user.IsAdmin); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (s *SQLStore) Create(user model.User) (*model.User, error) {
+	result, err := s.db.Exec(
+		`INSERT INTO users (name, email, password_hash, is_admin) VALUES (?, ?, ?, ?)`,
+		user.Name, user.Email, user.PasswordHash, user.IsAdmin,
+	)
+	if err != nil {
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+			return nil, ErrConflict
+		}
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	user.ID = int(id)
+	return &user, nil
+}
+
+func (s *SQLStore) Update(user model.User) error {
+	result, err := s.db.Exec(
+		`UPDATE users SET name = ?, email = ?, password_hash = ?, is_admin = ? WHERE id = ?`,
+		user.Name, user.Email, user.PasswordHash, user.IsAdmin, user.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *SQLStore) Delete(id int) error {
+	result, err := s.db.Exec(`DELETE FROM users WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *SQLStore) FindByEmail(email string) (*model.User, error) {
+	row := s.db.QueryRow(`SELECT id, name, email, password_hash, is_admin FROM users WHERE email = ?`, email)
+
+	var user model.User
+	if err := row.Scan(&user.ID, &user.Name, &user.Email, &user.PasswordHash, &user.IsAdmin); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
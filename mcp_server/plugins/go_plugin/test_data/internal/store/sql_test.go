@@ -0,0 +1,60 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/internal/model"
+)
+
+func TestSQLStore(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, s *SQLStore)
+	}{
+		{
+			name: "create then get",
+			run: func(t *testing.T, s *SQLStore) {
+				created, err := s.Create(model.User{Name: "Ada", Email: "ada@example.com"})
+				if err != nil {
+					t.Fatalf("Create() error = %v", err)
+				}
+				user, err := s.Get(created.ID)
+				if err != nil {
+					t.Fatalf("Get() error = %v", err)
+				}
+				if user.Email != "ada@example.com" {
+					t.Errorf("Email = %q, want %q", user.Email, "ada@example.com")
+				}
+			},
+		},
+		{
+			name: "get missing returns ErrNotFound",
+			run: func(t *testing.T, s *SQLStore) {
+				if _, err := s.Get(99); err != ErrNotFound {
+					t.Errorf("Get() error = %v, want %v", err, ErrNotFound)
+				}
+			},
+		},
+		{
+			name: "create with duplicate email returns ErrConflict",
+			run: func(t *testing.T, s *SQLStore) {
+				if _, err := s.Create(model.User{Name: "Ada", Email: "ada@example.com"}); err != nil {
+					t.Fatalf("Create() error = %v", err)
+				}
+				if _, err := s.Create(model.User{Name: "Ada Two", Email: "ada@example.com"}); err != ErrConflict {
+					t.Errorf("Create() error = %v, want %v", err, ErrConflict)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := OpenSQLStore(":memory:")
+			if err != nil {
+				t.Fatalf("OpenSQLStore() error = %v", err)
+			}
+			tt.run(t, s)
+		})
+	}
+}
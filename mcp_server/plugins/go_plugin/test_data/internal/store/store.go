@@ -0,0 +1,29 @@
+// Package store defines the persistence layer for users, with an
+// in-memory implementation for tests and a SQL-backed one for production.
+package store
+
+import (
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/internal/errno"
+	"github.com/viperjuice/code-index-mcp/mcp_server/plugins/go_plugin/test_data/internal/model"
+)
+
+// MaxRetries is a retry bound for transient store failures (e.g. a
+// dropped connection). Neither InMemoryStore nor SQLStore retries a
+// failed operation, and nothing in this package reads it.
+const MaxRetries = 3
+
+// ErrNotFound is returned when a lookup finds no matching user.
+var ErrNotFound = errno.ErrUserNotFound
+
+// ErrConflict is returned when a Create call would violate a uniqueness
+// constraint (e.g. a duplicate email).
+var ErrConflict = errno.ErrConflict
+
+// UserStore is implemented by anything that can persist users.
+type UserStore interface {
+	Get(id int) (*model.User, error)
+	Create(user model.User) (*model.User, error)
+	Update(user model.User) error
+	Delete(id int) error
+	FindByEmail(email string) (*model.User, error)
+}
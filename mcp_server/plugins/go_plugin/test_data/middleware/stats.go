@@ -0,0 +1,284 @@
+// Package middleware provides pluggable http.Handler wrappers for the
+// user service, such as request metrics collection.
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// routeStats accumulates counters and response-time samples for a single
+// route, guarded by the parent Stats mutex.
+type routeStats struct {
+	count           int64
+	statusCodeCount map[int]int64
+	responseBytes   int64
+	durations       []time.Duration // reservoir of sampled durations, used for percentiles
+}
+
+const reservoirSize = 1000
+
+// Stats wraps an http.Handler and records per-route counters: total
+// requests, responses bucketed by status code, total response bytes, and
+// a sampled response-time histogram. In-flight requests, status classes
+// and total response time are counted across all routes, not per route.
+type Stats struct {
+	next http.Handler
+
+	startedAt time.Time
+
+	mu         sync.RWMutex
+	totalCount int64
+	totalTime  time.Duration
+	byStatus   map[int]int64
+	byClass    map[string]int64
+	byRoute    map[string]*routeStats
+
+	inFlight int64 // guarded by mu, like everything else above
+}
+
+// NewStats wraps next with request metrics collection.
+func NewStats(next http.Handler) *Stats {
+	return &Stats{
+		next:      next,
+		startedAt: time.Now(),
+		byStatus:  make(map[int]int64),
+		byClass:   make(map[string]int64),
+		byRoute:   make(map[string]*routeStats),
+	}
+}
+
+// statusClass buckets an HTTP status code into its "2xx"/"3xx"/... class.
+func statusClass(status int) string {
+	return fmt.Sprintf("%dxx", status/100)
+}
+
+// statusRecorder captures the status code and byte count written through
+// an http.ResponseWriter, since the stdlib doesn't expose either.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+	return n, err
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Stats) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.incInFlight(1)
+	defer s.incInFlight(-1)
+
+	rec := &statusRecorder{ResponseWriter: w}
+	start := time.Now()
+	s.next.ServeHTTP(rec, r)
+	elapsed := time.Since(start)
+
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+
+	// r.Pattern holds the ServeMux pattern that matched (e.g.
+	// "GET /users/{id}"), set in place by the time ServeHTTP returns, also
+	// by a nested mux that receives this same *http.Request. A handler in
+	// between that passes on a copy (r.WithContext, as auth.RequireAuth
+	// does) leaves the outer mux's pattern here (e.g. "/users/"). Fall back
+	// to the literal path for requests a mux never matched (e.g. unrouted
+	// 404s) so they still get counted.
+	route := r.Pattern
+	if route == "" {
+		route = r.URL.Path
+	}
+	s.record(route, rec.status, rec.bytes, elapsed)
+}
+
+func (s *Stats) incInFlight(delta int64) {
+	s.mu.Lock()
+	s.inFlight += delta
+	s.mu.Unlock()
+}
+
+func (s *Stats) record(route string, status, bytes int, elapsed time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.totalCount++
+	s.totalTime += elapsed
+	s.byStatus[status]++
+	s.byClass[statusClass(status)]++
+
+	rs, ok := s.byRoute[route]
+	if !ok {
+		rs = &routeStats{statusCodeCount: make(map[int]int64)}
+		s.byRoute[route] = rs
+	}
+	rs.count++
+	rs.statusCodeCount[status]++
+	rs.responseBytes += int64(bytes)
+
+	// Algorithm R: the first reservoirSize samples are kept outright; after
+	// that, each new sample is admitted with probability reservoirSize/n
+	// and replaces a uniformly random existing slot, so the reservoir
+	// stays a uniform sample of all of the route's requests so far instead
+	// of freezing on its first reservoirSize requests.
+	if len(rs.durations) < reservoirSize {
+		rs.durations = append(rs.durations, elapsed)
+	} else if j := rand.Intn(int(rs.count)); j < reservoirSize {
+		rs.durations[j] = elapsed
+	}
+}
+
+// Snapshot is the JSON-serializable view of the current metrics,
+// returned by GET /stats.
+type Snapshot struct {
+	Uptime              string                `json:"uptime"`
+	TotalCount          int64                 `json:"total_count"`
+	TotalResponseTime   string                `json:"total_response_time"`
+	AverageResponseTime string                `json:"average_response_time"`
+	InFlight            int64                 `json:"in_flight"`
+	StatusCodeCount     map[int]int64         `json:"status_code_count"`
+	StatusClassCount    map[string]int64      `json:"status_class_count"`
+	CountPerRoute       map[string]RouteStats `json:"count_per_route"`
+}
+
+// RouteStats is the per-route portion of a Snapshot.
+type RouteStats struct {
+	Count           int64         `json:"count"`
+	StatusCodeCount map[int]int64 `json:"status_code_count"`
+	ResponseBytes   int64         `json:"response_bytes"`
+	MinResponseTime string        `json:"min_response_time"`
+	MaxResponseTime string        `json:"max_response_time"`
+	P50             string        `json:"p50"`
+	P95             string        `json:"p95"`
+	P99             string        `json:"p99"`
+}
+
+// Snapshot returns a consistent, point-in-time copy of the collected metrics.
+func (s *Stats) Snapshot() Snapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snap := Snapshot{
+		Uptime:           time.Since(s.startedAt).String(),
+		TotalCount:       s.totalCount,
+		InFlight:         s.inFlight,
+		StatusCodeCount:  copyStatusCounts(s.byStatus),
+		StatusClassCount: copyClassCounts(s.byClass),
+		CountPerRoute:    make(map[string]RouteStats, len(s.byRoute)),
+	}
+
+	snap.TotalResponseTime = s.totalTime.String()
+	if s.totalCount > 0 {
+		snap.AverageResponseTime = (s.totalTime / time.Duration(s.totalCount)).String()
+	}
+
+	for route, rs := range s.byRoute {
+		snap.CountPerRoute[route] = routeSnapshot(rs)
+	}
+
+	return snap
+}
+
+func routeSnapshot(rs *routeStats) RouteStats {
+	sorted := append([]time.Duration(nil), rs.durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	out := RouteStats{
+		Count:           rs.count,
+		StatusCodeCount: copyStatusCounts(rs.statusCodeCount),
+		ResponseBytes:   rs.responseBytes,
+	}
+
+	if len(sorted) > 0 {
+		out.MinResponseTime = sorted[0].String()
+		out.MaxResponseTime = sorted[len(sorted)-1].String()
+		out.P50 = percentile(sorted, 0.50).String()
+		out.P95 = percentile(sorted, 0.95).String()
+		out.P99 = percentile(sorted, 0.99).String()
+	}
+
+	return out
+}
+
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(p * float64(len(sorted)-1))
+	return sorted[idx]
+}
+
+func copyStatusCounts(in map[int]int64) map[int]int64 {
+	out := make(map[int]int64, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
+
+func copyClassCounts(in map[string]int64) map[string]int64 {
+	out := make(map[string]int64, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
+
+// StatsHandler serves GET /stats with the aggregated JSON snapshot.
+func StatsHandler(s *Stats) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(s.Snapshot())
+	}
+}
+
+// MetricsHandler serves GET /metrics in Prometheus text exposition format
+// so the same counters are scrapeable alongside the JSON snapshot.
+func MetricsHandler(s *Stats) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		snap := s.Snapshot()
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		fmt.Fprintf(w, "# HELP userservice_requests_total Total number of HTTP requests.\n")
+		fmt.Fprintf(w, "# TYPE userservice_requests_total counter\n")
+		fmt.Fprintf(w, "userservice_requests_total %d\n", snap.TotalCount)
+
+		fmt.Fprintf(w, "# HELP userservice_requests_by_status_total Total requests by status code.\n")
+		fmt.Fprintf(w, "# TYPE userservice_requests_by_status_total counter\n")
+		for status, count := range snap.StatusCodeCount {
+			fmt.Fprintf(w, "userservice_requests_by_status_total{status=\"%d\"} %d\n", status, count)
+		}
+
+		fmt.Fprintf(w, "# HELP userservice_requests_by_status_class_total Total requests by status class.\n")
+		fmt.Fprintf(w, "# TYPE userservice_requests_by_status_class_total counter\n")
+		for class, count := range snap.StatusClassCount {
+			fmt.Fprintf(w, "userservice_requests_by_status_class_total{class=%q} %d\n", class, count)
+		}
+
+		fmt.Fprintf(w, "# HELP userservice_in_flight_requests Current number of in-flight requests.\n")
+		fmt.Fprintf(w, "# TYPE userservice_in_flight_requests gauge\n")
+		fmt.Fprintf(w, "userservice_in_flight_requests %d\n", snap.InFlight)
+
+		fmt.Fprintf(w, "# HELP userservice_route_requests_total Total requests by route.\n")
+		fmt.Fprintf(w, "# TYPE userservice_route_requests_total counter\n")
+		for route, rs := range snap.CountPerRoute {
+			fmt.Fprintf(w, "userservice_route_requests_total{route=%q} %d\n", route, rs.Count)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newTestMux mirrors the method-aware pattern style used by
+// internal/handler.NewRouter, so Stats sees a real r.Pattern to key on.
+func newTestMux() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not found", http.StatusNotFound)
+	})
+	return mux
+}
+
+func TestStatsSnapshot(t *testing.T) {
+	s := NewStats(newTestMux())
+
+	reqs := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodGet, "/users/1"},
+		{http.MethodGet, "/users/2"},
+		{http.MethodGet, "/missing"},
+	}
+	for _, req := range reqs {
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, httptest.NewRequest(req.method, req.path, nil))
+	}
+
+	snap := s.Snapshot()
+
+	if snap.TotalCount != 3 {
+		t.Errorf("TotalCount = %d, want %d", snap.TotalCount, 3)
+	}
+	if snap.StatusCodeCount[http.StatusOK] != 2 {
+		t.Errorf("StatusCodeCount[200] = %d, want %d", snap.StatusCodeCount[http.StatusOK], 2)
+	}
+	if snap.StatusCodeCount[http.StatusNotFound] != 1 {
+		t.Errorf("StatusCodeCount[404] = %d, want %d", snap.StatusCodeCount[http.StatusNotFound], 1)
+	}
+	if snap.StatusClassCount["2xx"] != 2 {
+		t.Errorf("StatusClassCount[2xx] = %d, want %d", snap.StatusClassCount["2xx"], 2)
+	}
+	if snap.StatusClassCount["4xx"] != 1 {
+		t.Errorf("StatusClassCount[4xx] = %d, want %d", snap.StatusClassCount["4xx"], 1)
+	}
+	if snap.InFlight != 0 {
+		t.Errorf("InFlight = %d, want %d", snap.InFlight, 0)
+	}
+
+	if len(snap.CountPerRoute) != 2 {
+		t.Fatalf("CountPerRoute has %d entries, want %d: %v", len(snap.CountPerRoute), 2, snap.CountPerRoute)
+	}
+
+	route := snap.CountPerRoute["GET /users/{id}"]
+	if route.Count != 2 {
+		t.Errorf("CountPerRoute[GET /users/{id}].Count = %d, want %d", route.Count, 2)
+	}
+}
+
+// TestStatsSnapshotCollapsesPathParameters guards against per-route keys
+// being built from the literal path: hitting N distinct user IDs must
+// collapse into a single "GET /users/{id}" bucket, not N buckets.
+func TestStatsSnapshotCollapsesPathParameters(t *testing.T) {
+	s := NewStats(newTestMux())
+
+	for id := 1; id <= 50; id++ {
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/users/%d", id), nil))
+	}
+
+	snap := s.Snapshot()
+	if len(snap.CountPerRoute) != 1 {
+		t.Fatalf("CountPerRoute has %d entries, want 1: %v", len(snap.CountPerRoute), snap.CountPerRoute)
+	}
+	if got := snap.CountPerRoute["GET /users/{id}"].Count; got != 50 {
+		t.Errorf("CountPerRoute[GET /users/{id}].Count = %d, want %d", got, 50)
+	}
+}
+
+// TestStatsReservoirRolls guards against the duration reservoir freezing
+// once full: samples recorded after reservoirSize must still be able to
+// displace earlier ones, not just be dropped.
+func TestStatsReservoirRolls(t *testing.T) {
+	s := NewStats(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for i := 0; i < reservoirSize; i++ {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/warm", nil)
+		req.Pattern = "GET /warm"
+		s.ServeHTTP(rec, req)
+	}
+
+	rs := s.byRoute["GET /warm"]
+	before := append([]time.Duration(nil), rs.durations...)
+
+	for i := 0; i < reservoirSize; i++ {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/warm", nil)
+		req.Pattern = "GET /warm"
+		s.ServeHTTP(rec, req)
+	}
+
+	if len(rs.durations) != reservoirSize {
+		t.Fatalf("len(durations) = %d, want %d", len(rs.durations), reservoirSize)
+	}
+
+	changed := false
+	for i, d := range rs.durations {
+		if d != before[i] {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		t.Error("reservoir is unchanged after reservoirSize more samples; replacement never happened")
+	}
+}
+
+func TestStatsInFlight(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusOK)
+	})
+
+	s := NewStats(next)
+
+	done := make(chan struct{})
+	go func() {
+		s.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/slow", nil))
+		close(done)
+	}()
+
+	<-started
+	if got := s.Snapshot().InFlight; got != 1 {
+		t.Errorf("InFlight during request = %d, want %d", got, 1)
+	}
+
+	close(release)
+	<-done
+
+	if got := s.Snapshot().InFlight; got != 0 {
+		t.Errorf("InFlight after request = %d, want %d", got, 0)
+	}
+}
@@ -1,76 +0,0 @@
-package main
-
-import (
-	"fmt"
-	"net/http"
-	"encoding/json"
-)
-
-// User represents a user in the system
-type User struct {
-	ID    int    `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
-}
-
-// UserService defines the interface for user operations
-type UserService interface {
-	GetUser(id int) (*User, error)
-	CreateUser(user User) error
-	UpdateUser(user User) error
-	DeleteUser(id int) error
-}
-
-// Status represents the application status
-type Status string
-
-const (
-	StatusActive   Status = "active"
-	StatusInactive Status = "inactive"
-	StatusPending  Status = "pending"
-)
-
-const MaxRetries = 3
-
-var (
-	defaultTimeout = 30
-	apiVersion     = "v1"
-)
-
-// NewUser creates a new user instance
-func NewUser(name, email string) *User {
-	return &User{
-		Name:  name,
-		Email: email,
-	}
-}
-
-// GetUserByID retrieves a user by their ID
-func (s *userServiceImpl) GetUser(id int) (*User, error) {
-	if id <= 0 {
-		return nil, fmt.Errorf("invalid user ID: %d", id)
-	}
-	
-	// Database lookup logic here
-	return &User{ID: id}, nil
-}
-
-// HandleUserRequest handles HTTP requests for user operations
-func HandleUserRequest(w http.ResponseWriter, r *http.Request) {
-	switch r.Method {
-	case http.MethodGet:
-		// Handle GET request
-		getUserHandler(w, r)
-	case http.MethodPost:
-		// Handle POST request
-		createUserHandler(w, r)
-	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-	}
-}
-
-func main() {
-	http.HandleFunc("/users", HandleUserRequest)
-	fmt.Println("Server starting on port 8080")
-	http.ListenAndServe(":8080", nil)
-}
\ No newline at end of file